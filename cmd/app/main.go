@@ -1,35 +1,106 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"log"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"namespace-manager/internal/auth"
+	"namespace-manager/internal/config"
+	"namespace-manager/internal/controller"
 	"namespace-manager/internal/httpserver"
 	"namespace-manager/internal/kube"
+	"namespace-manager/internal/reaper"
 )
 
 func main() {
+	dryRun := flag.Bool("dry-run", false, "log namespaces the reaper would delete instead of actually deleting them")
+	reapInterval := flag.Duration("reap-interval", time.Minute, "how often the reaper reconciles on a timer, in addition to reacting to namespace events")
+	extensionPolicyPath := flag.String("extension-policy", "", "path to a YAML file capping per-team POST /api/v1/namespaces/{name}:extend hours (optional)")
+	authPolicyPath := flag.String("auth-policy", "", "path to a YAML file mapping groups to allowed teams and TTL caps; enables auth middleware when set")
+	authPolicyReload := flag.Duration("auth-policy-reload-interval", 30*time.Second, "how often to reload --auth-policy from disk")
+	flag.Parse()
+
 	// Create Kubernetes client first - this will be used by handlers to interact with Kubernetes
 	kubeClient, err := kube.NewClient()
 	if err != nil {
 		log.Fatalf("Failed to create kube client: %v", err)
 	}
-	
+
 	// Create a new server that will listen on port 8080
 	// Pass the Kubernetes client so handlers can use it
 	server := httpserver.NewServer(":8080", kubeClient)
-	
-	// Register the root path "/" with the HandleRoot handler
-	// This means: when someone visits http://localhost:8080/, 
-	// the HandleRoot function will be called to handle the request
-	server.RegisterRoute("/api/namespaces/create", server.HandleCreateNamespaceRequest) 
 
-	server.RegisterRoute("/api/namespaces/delete", server.HandleDeleteNamespaceRequest)
+	// Wire up auth before registering routes, since Handle wraps each
+	// handler with the auth middleware at registration time.
+	if *authPolicyPath != "" {
+		server.SetAuthMiddleware(auth.Middleware(auth.NewKubeTokenReviewer(kubeClient.Clientset())))
+
+		policyStore, err := auth.NewPolicyStore(*authPolicyPath)
+		if err != nil {
+			log.Fatalf("Failed to load auth policy: %v", err)
+		}
+		go policyStore.Watch(context.Background(), *authPolicyReload)
+		server.SetPolicyStore(policyStore)
+	}
+
+	// Register the versioned REST API. GET/POST /api/v1/namespaces and the
+	// per-namespace routes below replace the old flat /api/namespaces/*
+	// action endpoints.
+	server.Handle(http.MethodGet, "/api/v1/namespaces", server.HandleListNamespacesRequest)
+	server.Handle(http.MethodPost, "/api/v1/namespaces", server.HandleCreateNamespaceRequest)
+	server.Handle(http.MethodGet, "/api/v1/namespaces:expiring", server.HandleExpiringNamespacesRequest)
+	server.Handle(http.MethodGet, "/api/v1/namespaces/{name}", server.HandleGetNamespaceRequest)
+	server.Handle(http.MethodDelete, "/api/v1/namespaces/{name}", server.HandleDeleteNamespaceRequest)
+	server.Handle(http.MethodPost, "/api/v1/namespaces/{name}:extend", server.HandleExtendNamespaceRequest)
+
+	// OpenAPI document and Swagger UI stay reachable without a token, same
+	// as /metrics, so operators and API consumers can discover the API
+	// before they have credentials for it.
+	server.HandlePublic(http.MethodGet, "/openapi.json", server.HandleOpenAPI)
+	server.HandlePublic(http.MethodGet, "/docs", server.HandleDocs)
+	server.HandlePublic(http.MethodGet, "/metrics", promhttp.Handler().ServeHTTP)
+
+	if *extensionPolicyPath != "" {
+		policy, err := config.LoadExtensionPolicy(*extensionPolicyPath)
+		if err != nil {
+			log.Fatalf("Failed to load extension policy: %v", err)
+		}
+		server.SetExtensionPolicy(policy)
+	}
+
+	// Start the TTL reaper, which enforces the expires_at annotation the
+	// managednamespace controller sets on every namespace it reconciles. It
+	// races for leadership first so that running multiple replicas of
+	// namespace-manager doesn't cause duplicate deletions.
+	r := reaper.New(kubeClient, *reapInterval, *dryRun)
+	server.SetExpiryLister(r)
+	go func() {
+		if err := reaper.RunWithLeaderElection(context.Background(), kubeClient.Clientset(), r); err != nil {
+			log.Printf("reaper: stopped: %v", err)
+		}
+	}()
+
+	// Start the managednamespace controller, which reconciles ManagedNamespace
+	// CRs into real corev1.Namespaces. It races for leadership first, same as
+	// the reaper, so that running multiple replicas of namespace-manager
+	// doesn't cause them to reconcile (and create/patch) the same
+	// ManagedNamespace concurrently.
+	mnController := controller.New(kubeClient)
+	go func() {
+		if err := controller.RunWithLeaderElection(context.Background(), kubeClient.Clientset(), mnController); err != nil {
+			log.Printf("controller: stopped: %v", err)
+		}
+	}()
 
-	server.RegisterRoute("/api/namespaces/list", server.HandleListNamespacesRequest)
-	
 	// Start the server and begin listening for incoming HTTP requests
 	// This will block (keep running) until the server stops
 	log.Println("Server starting on port 8080")
 	if err := server.ListenAndServe(); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,133 @@
+// Package v1alpha1 contains the typed API for the ManagedNamespace custom
+// resource: group nsmgr.io, version v1alpha1. These types mirror the CRD
+// schema installed alongside the controller (see the CRD manifest) and are
+// (de)serialized to/from unstructured objects via runtime's default
+// converter rather than a generated clientset, since namespace-manager
+// doesn't otherwise depend on client-gen.
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	GroupName = "nsmgr.io"
+	Version   = "v1alpha1"
+	Kind      = "ManagedNamespace"
+	ListKind  = "ManagedNamespaceList"
+	Plural    = "managednamespaces"
+	Singular  = "managednamespace"
+)
+
+// SchemeGroupVersion is the group/version for this API, used when setting
+// TypeMeta on objects we construct ourselves.
+var SchemeGroupVersion = schema.GroupVersion{Group: GroupName, Version: Version}
+
+// Resource returns the GroupVersionResource for ManagedNamespace, for use
+// with a dynamic.Interface.
+func Resource() schema.GroupVersionResource {
+	return SchemeGroupVersion.WithResource(Plural)
+}
+
+// ManagedNamespacePhase is the lifecycle phase recorded in
+// ManagedNamespace.Status.Phase.
+type ManagedNamespacePhase string
+
+const (
+	PhasePending     ManagedNamespacePhase = "Pending"
+	PhaseActive      ManagedNamespacePhase = "Active"
+	PhaseTerminating ManagedNamespacePhase = "Terminating"
+	PhaseFailed      ManagedNamespacePhase = "Failed"
+)
+
+// ManagedNamespaceSpec is the desired state of a managed namespace,
+// submitted by users instead of raw corev1.Namespace annotations.
+type ManagedNamespaceSpec struct {
+	// Name is the namespace name to create. Immutable after creation.
+	Name string `json:"name"`
+	// TTL is the namespace's lifetime in hours from creation, enforced by
+	// the reaper. Extensions push Status.ExpiresAt further out without
+	// changing TTL.
+	TTL int `json:"ttl"`
+	// Owner is the user responsible for the namespace.
+	Owner string `json:"owner"`
+	// Team the namespace belongs to, used for RBAC and extension policy.
+	Team string `json:"team"`
+	// Purpose is a free-form human-readable description of what the
+	// namespace is for.
+	Purpose string `json:"purpose,omitempty"`
+	// AutoExtend, when true, tells the controller to push expires_at
+	// forward by TTL each time it's about to lapse instead of letting the
+	// reaper delete it.
+	AutoExtend bool `json:"autoExtend,omitempty"`
+	// WaitTerminatingSeconds bounds how long the controller waits for a
+	// same-named namespace stuck Terminating from a previous delete to
+	// fully disappear before retrying creation. Defaults to 60 if unset.
+	WaitTerminatingSeconds int `json:"waitTerminatingSeconds,omitempty"`
+	// GracePeriodHours, if set, is carried onto the namespace's
+	// reaper.GracePeriodAnnotation so the reaper delays reaping past
+	// expires_at by this many hours instead of reaping right away.
+	GracePeriodHours int `json:"gracePeriodHours,omitempty"`
+	// Protected, if true, is carried onto the namespace's
+	// reaper.ProtectedAnnotation, exempting it from reaping entirely
+	// regardless of expires_at.
+	Protected bool `json:"protected,omitempty"`
+}
+
+// Condition is a standard Kubernetes-style status condition.
+type Condition struct {
+	Type               string      `json:"type"`
+	Status             string      `json:"status"` // "True", "False", or "Unknown"
+	Reason             string      `json:"reason,omitempty"`
+	Message            string      `json:"message,omitempty"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// ManagedNamespaceStatus is the observed state, written by the controller.
+type ManagedNamespaceStatus struct {
+	Phase          ManagedNamespacePhase `json:"phase,omitempty"`
+	ExpiresAt      metav1.Time           `json:"expiresAt,omitempty"`
+	LastExtendedAt metav1.Time           `json:"lastExtendedAt,omitempty"`
+	Conditions     []Condition           `json:"conditions,omitempty"`
+}
+
+// ManagedNamespace is the typed representation of a nsmgr.io/v1alpha1
+// ManagedNamespace custom resource.
+type ManagedNamespace struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ManagedNamespaceSpec   `json:"spec"`
+	Status ManagedNamespaceStatus `json:"status,omitempty"`
+}
+
+// ReconcileFailureReason returns the Reason of the Reconciled condition when
+// Phase is Failed, or "" otherwise - letting API callers (and their own
+// backoff logic) learn why reconciliation failed without having to inspect
+// Status.Conditions themselves.
+func (mn *ManagedNamespace) ReconcileFailureReason() string {
+	if mn.Status.Phase != PhaseFailed {
+		return ""
+	}
+	for _, c := range mn.Status.Conditions {
+		if c.Type == "Reconciled" && c.Status == "False" {
+			return c.Reason
+		}
+	}
+	return ""
+}
+
+// ExtensionHistoryAnnotation records every extend call applied to a
+// ManagedNamespace as a JSON array of ExtensionRecord, oldest first.
+const ExtensionHistoryAnnotation = "nsmgr.io/extension-history"
+
+// ExtensionRecord is one entry in the ExtensionHistoryAnnotation audit
+// trail.
+type ExtensionRecord struct {
+	By    string    `json:"by"`
+	At    time.Time `json:"at"`
+	Hours int       `json:"hours"`
+}
@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+)
+
+// AuditEvent is one structured audit log line emitted for a mutating API
+// call - every create/delete/extend - so decisions are traceable after the
+// fact.
+type AuditEvent struct {
+	Time     time.Time `json:"time"`
+	User     string    `json:"user"`
+	Groups   []string  `json:"groups,omitempty"`
+	Action   string    `json:"action"`
+	Resource string    `json:"resource,omitempty"`
+	Team     string    `json:"team,omitempty"`
+	Decision string    `json:"decision"` // "allow", "deny", or "error"
+	Reason   string    `json:"reason,omitempty"`
+}
+
+// Audit logs event as a single JSON line.
+func Audit(event AuditEvent) {
+	event.Time = time.Now()
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("audit: failed to marshal event: %v", err)
+		return
+	}
+	log.Println(string(data))
+}
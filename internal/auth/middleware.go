@@ -0,0 +1,69 @@
+// Package auth authenticates incoming requests against the Kubernetes API
+// server's TokenReview endpoint and enforces a group-to-team policy on top
+// of the identity it establishes.
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// User is the identity the middleware populates into the request context
+// once a bearer token has been validated.
+type User struct {
+	Username string
+	Groups   []string
+}
+
+type contextKey string
+
+const userContextKey contextKey = "auth.user"
+
+// TokenReviewer validates a bearer token and returns the identity it maps
+// to. KubeTokenReviewer is the production implementation, backed by the
+// API server's TokenReview endpoint.
+type TokenReviewer interface {
+	ReviewToken(ctx context.Context, token string) (*User, error)
+}
+
+// Middleware extracts a bearer token from the Authorization header,
+// validates it via reviewer, and populates the request context with the
+// resulting User before calling next. Requests with a missing or invalid
+// token are rejected with 401 and never reach next.
+func Middleware(reviewer TokenReviewer) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := bearerToken(r)
+			if token == "" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte("Missing bearer token"))
+				return
+			}
+
+			user, err := reviewer.ReviewToken(r.Context(), token)
+			if err != nil {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte("Invalid token: " + err.Error()))
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userContextKey, user)))
+		})
+	}
+}
+
+// UserFromContext returns the User the middleware populated, if any.
+func UserFromContext(ctx context.Context) (*User, bool) {
+	user, ok := ctx.Value(userContextKey).(*User)
+	return user, ok
+}
+
+func bearerToken(r *http.Request) string {
+	const prefix = "Bearer "
+	header := r.Header.Get("Authorization")
+	if len(header) <= len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return ""
+	}
+	return header[len(prefix):]
+}
@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"context"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Policy maps the groups a TokenReview returns to the teams their members
+// may act on, plus per-team limits those members are bound by.
+type Policy struct {
+	// GroupTeams maps a Kubernetes group to the teams its members may
+	// create and manage namespaces for.
+	GroupTeams map[string][]string `json:"groupTeams"`
+	// TeamTTLCapHours caps the TTL a namespace may be created with, per
+	// team. Teams not listed are uncapped.
+	TeamTTLCapHours map[string]int `json:"teamTTLCapHours"`
+	// TeamAdminGroups lists groups allowed to delete any namespace
+	// belonging to a team, not just ones they own.
+	TeamAdminGroups map[string][]string `json:"teamAdminGroups"`
+}
+
+// AllowedTeam reports whether any of groups maps to team.
+func (p *Policy) AllowedTeam(team string, groups []string) bool {
+	if p == nil {
+		return false
+	}
+	for _, g := range groups {
+		for _, t := range p.GroupTeams[g] {
+			if t == team {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// TTLCap returns the configured TTL cap for team, if one is set.
+func (p *Policy) TTLCap(team string) (int, bool) {
+	if p == nil {
+		return 0, false
+	}
+	hours, ok := p.TeamTTLCapHours[team]
+	return hours, ok
+}
+
+// IsTeamAdmin reports whether any of groups is an admin group for team.
+func (p *Policy) IsTeamAdmin(team string, groups []string) bool {
+	if p == nil {
+		return false
+	}
+	for _, g := range groups {
+		for _, admin := range p.TeamAdminGroups[team] {
+			if admin == g {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PolicyStore holds the current Policy loaded from a YAML file and
+// periodically reloads it, so operators can edit the file in place
+// without restarting the server.
+type PolicyStore struct {
+	path string
+
+	mu     sync.RWMutex
+	policy *Policy
+}
+
+// NewPolicyStore loads path and returns a PolicyStore. Call Watch
+// afterwards to pick up subsequent edits.
+func NewPolicyStore(path string) (*PolicyStore, error) {
+	ps := &PolicyStore{path: path}
+	if err := ps.reload(); err != nil {
+		return nil, err
+	}
+	return ps, nil
+}
+
+// Current returns the most recently loaded Policy.
+func (ps *PolicyStore) Current() *Policy {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+	return ps.policy
+}
+
+// Watch reloads the policy file every interval until ctx is cancelled. It
+// blocks, so callers should run it in its own goroutine.
+func (ps *PolicyStore) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := ps.reload(); err != nil {
+				log.Printf("auth: failed to reload policy from %s: %v", ps.path, err)
+			}
+		}
+	}
+}
+
+func (ps *PolicyStore) reload() error {
+	data, err := os.ReadFile(ps.path)
+	if err != nil {
+		return err
+	}
+
+	var policy Policy
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return err
+	}
+
+	ps.mu.Lock()
+	ps.policy = &policy
+	ps.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,72 @@
+package auth
+
+import "testing"
+
+func TestPolicyAllowedTeam(t *testing.T) {
+	p := &Policy{
+		GroupTeams: map[string][]string{
+			"platform-eng": {"platform", "infra"},
+			"web-eng":      {"web"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		team   string
+		groups []string
+		want   bool
+	}{
+		{name: "group maps to team", team: "infra", groups: []string{"platform-eng"}, want: true},
+		{name: "group doesn't map to team", team: "web", groups: []string{"platform-eng"}, want: false},
+		{name: "one of several groups matches", team: "web", groups: []string{"platform-eng", "web-eng"}, want: true},
+		{name: "unknown group", team: "web", groups: []string{"nobody"}, want: false},
+		{name: "no groups", team: "web", groups: nil, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.AllowedTeam(tt.team, tt.groups); got != tt.want {
+				t.Errorf("AllowedTeam(%q, %v) = %v, want %v", tt.team, tt.groups, got, tt.want)
+			}
+		})
+	}
+
+	var nilPolicy *Policy
+	if nilPolicy.AllowedTeam("web", []string{"web-eng"}) {
+		t.Error("nil Policy should deny every team")
+	}
+}
+
+func TestPolicyTTLCap(t *testing.T) {
+	p := &Policy{TeamTTLCapHours: map[string]int{"web": 48}}
+
+	if hours, ok := p.TTLCap("web"); !ok || hours != 48 {
+		t.Errorf("TTLCap(web) = (%d, %v), want (48, true)", hours, ok)
+	}
+	if _, ok := p.TTLCap("platform"); ok {
+		t.Error("TTLCap(platform) should be unset, and unset teams are uncapped")
+	}
+
+	var nilPolicy *Policy
+	if _, ok := nilPolicy.TTLCap("web"); ok {
+		t.Error("nil Policy should report no cap")
+	}
+}
+
+func TestPolicyIsTeamAdmin(t *testing.T) {
+	p := &Policy{TeamAdminGroups: map[string][]string{"web": {"web-leads"}}}
+
+	if !p.IsTeamAdmin("web", []string{"web-eng", "web-leads"}) {
+		t.Error("expected web-leads to be a web team admin")
+	}
+	if p.IsTeamAdmin("web", []string{"web-eng"}) {
+		t.Error("web-eng alone should not be a web team admin")
+	}
+	if p.IsTeamAdmin("platform", []string{"web-leads"}) {
+		t.Error("web-leads should not admin a team it isn't listed for")
+	}
+
+	var nilPolicy *Policy
+	if nilPolicy.IsTeamAdmin("web", []string{"web-leads"}) {
+		t.Error("nil Policy should deny every admin check")
+	}
+}
@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// KubeTokenReviewer validates bearer tokens by submitting a TokenReview to
+// the API server - the same check kubelet and webhook authenticators use.
+type KubeTokenReviewer struct {
+	clientset kubernetes.Interface
+}
+
+// NewKubeTokenReviewer creates a KubeTokenReviewer backed by clientset.
+func NewKubeTokenReviewer(clientset kubernetes.Interface) *KubeTokenReviewer {
+	return &KubeTokenReviewer{clientset: clientset}
+}
+
+// ReviewToken implements TokenReviewer.
+func (k *KubeTokenReviewer) ReviewToken(ctx context.Context, token string) (*User, error) {
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}
+
+	result, err := k.clientset.AuthenticationV1().TokenReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("submitting token review: %w", err)
+	}
+	if !result.Status.Authenticated {
+		return nil, fmt.Errorf("token not authenticated: %s", result.Status.Error)
+	}
+
+	return &User{
+		Username: result.Status.User.Username,
+		Groups:   result.Status.User.Groups,
+	}, nil
+}
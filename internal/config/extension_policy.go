@@ -0,0 +1,41 @@
+// Package config loads namespace-manager's policy configuration - currently
+// just the per-team extension cap, though this is where other
+// policy-as-YAML settings should land as they're added.
+package config
+
+import (
+	"os"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ExtensionPolicy caps how many hours a single
+// POST /api/v1/namespaces/{name}:extend call may add, per team.
+type ExtensionPolicy struct {
+	TeamMaxExtensionHours map[string]int `json:"teamMaxExtensionHours"`
+}
+
+// LoadExtensionPolicy reads an ExtensionPolicy from a YAML file.
+func LoadExtensionPolicy(path string) (*ExtensionPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var policy ExtensionPolicy
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// MaxExtensionHours returns the configured cap for team, if one is set. The
+// second return value is false when the team has no configured cap, in
+// which case callers should treat the extension as unbounded.
+func (p *ExtensionPolicy) MaxExtensionHours(team string) (int, bool) {
+	if p == nil {
+		return 0, false
+	}
+	hours, ok := p.TeamMaxExtensionHours[team]
+	return hours, ok
+}
@@ -0,0 +1,19 @@
+package config
+
+import "testing"
+
+func TestExtensionPolicyMaxExtensionHours(t *testing.T) {
+	p := &ExtensionPolicy{TeamMaxExtensionHours: map[string]int{"web": 24}}
+
+	if hours, ok := p.MaxExtensionHours("web"); !ok || hours != 24 {
+		t.Errorf("MaxExtensionHours(web) = (%d, %v), want (24, true)", hours, ok)
+	}
+	if _, ok := p.MaxExtensionHours("platform"); ok {
+		t.Error("MaxExtensionHours(platform) should be unset, and unset teams are uncapped")
+	}
+
+	var nilPolicy *ExtensionPolicy
+	if _, ok := nilPolicy.MaxExtensionHours("web"); ok {
+		t.Error("nil ExtensionPolicy should report no cap")
+	}
+}
@@ -0,0 +1,20 @@
+package controller
+
+import (
+	"context"
+
+	"k8s.io/client-go/kubernetes"
+
+	"namespace-manager/internal/leader"
+)
+
+const leaseName = "namespace-manager-controller"
+
+// RunWithLeaderElection runs ctrl.Start only while this process holds the
+// controller's lease, so that multiple replicas of namespace-manager don't
+// race each other reconciling (and creating/patching) the same
+// ManagedNamespace. It blocks until ctx is cancelled or this process loses
+// the lease, and does not campaign for it again after losing it.
+func RunWithLeaderElection(ctx context.Context, clientset kubernetes.Interface, ctrl *ManagedNamespaceController) error {
+	return leader.Run(ctx, clientset, leaseName, "controller", ctrl)
+}
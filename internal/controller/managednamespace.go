@@ -0,0 +1,238 @@
+// Package controller reconciles ManagedNamespace custom resources into
+// real corev1.Namespace objects, so users get kubectl get managednamespaces
+// visibility and RBAC on the CRD while the rest of namespace-manager (the
+// reaper, in particular) keeps working against plain namespace annotations -
+// this package carries Spec.GracePeriodHours/Protected onto the reaper's
+// own annotations so those reaper features stay reachable from the CRD.
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+
+	nsmgrv1alpha1 "namespace-manager/internal/apis/nsmgr/v1alpha1"
+	"namespace-manager/internal/kube"
+	"namespace-manager/internal/reaper"
+)
+
+const (
+	resyncPeriod = 10 * time.Minute
+
+	// autoExtendWindow is how far ahead of expiresAt Spec.AutoExtend kicks
+	// in. It matches resyncPeriod so a namespace can't slip past its
+	// deadline between two reconciles of an otherwise-untouched
+	// ManagedNamespace. The rolled-forward expiresAt below only takes
+	// effect because reconcileNamespace patches it onto the real
+	// namespace's expires_at annotation on every reconcile, not just on
+	// create - otherwise the reaper would still go by the original value.
+	autoExtendWindow = resyncPeriod
+)
+
+// ManagedNamespaceController watches ManagedNamespace custom resources and
+// reconciles each one into a matching corev1.Namespace.
+type ManagedNamespaceController struct {
+	kubeClient *kube.Client
+	factory    dynamicinformer.DynamicSharedInformerFactory
+}
+
+// New creates a ManagedNamespaceController.
+func New(kubeClient *kube.Client) *ManagedNamespaceController {
+	return &ManagedNamespaceController{
+		kubeClient: kubeClient,
+		factory:    dynamicinformer.NewDynamicSharedInformerFactory(kubeClient.Dynamic(), resyncPeriod),
+	}
+}
+
+// Start wires up the ManagedNamespace informer and reconciles on every
+// add/update event until ctx is cancelled. It blocks, so callers should run
+// it in its own goroutine.
+func (ctrl *ManagedNamespaceController) Start(ctx context.Context) error {
+	informer := ctrl.factory.ForResource(nsmgrv1alpha1.Resource()).Informer()
+
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { ctrl.handle(obj) },
+		UpdateFunc: func(_, newObj interface{}) { ctrl.handle(newObj) },
+	})
+	if err != nil {
+		return err
+	}
+
+	ctrl.factory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		return ctx.Err()
+	}
+
+	log.Println("controller: managednamespace controller started")
+	<-ctx.Done()
+	log.Println("controller: managednamespace controller stopping")
+	return nil
+}
+
+func (ctrl *ManagedNamespaceController) handle(obj interface{}) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	if err := ctrl.reconcile(u.GetName()); err != nil {
+		log.Printf("controller: failed to reconcile ManagedNamespace %q: %v", u.GetName(), err)
+	}
+}
+
+// reconcile fetches the current ManagedNamespace by name and makes the
+// cluster match it: create the corev1.Namespace if missing, then update
+// Status to reflect what actually exists.
+func (ctrl *ManagedNamespaceController) reconcile(name string) error {
+	mn, err := ctrl.kubeClient.GetManagedNamespace(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Deleted - nothing to do, the owner reference on the
+			// namespace will have Kubernetes garbage-collect it.
+			return nil
+		}
+		return err
+	}
+
+	expiresAt := mn.Status.ExpiresAt.Time
+	if expiresAt.IsZero() {
+		expiresAt = mn.CreationTimestamp.Add(time.Duration(mn.Spec.TTL) * time.Hour)
+	} else if mn.Spec.AutoExtend && time.Until(expiresAt) < autoExtendWindow {
+		// About to lapse - push it forward by another full TTL instead of
+		// letting the reaper delete it.
+		expiresAt = expiresAt.Add(time.Duration(mn.Spec.TTL) * time.Hour)
+	}
+
+	desired := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: mn.Spec.Name,
+			Labels: map[string]string{
+				"nsmgr.io/managed": "true",
+			},
+			Annotations: map[string]string{
+				"owner":      mn.Spec.Owner,
+				"team":       mn.Spec.Team,
+				"expires_at": expiresAt.Format(time.RFC3339),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: nsmgrv1alpha1.SchemeGroupVersion.String(),
+					Kind:       nsmgrv1alpha1.Kind,
+					Name:       mn.Name,
+					UID:        mn.UID,
+				},
+			},
+		},
+	}
+	if mn.Spec.Purpose != "" {
+		desired.Annotations["purpose"] = mn.Spec.Purpose
+	}
+	if mn.Spec.GracePeriodHours > 0 {
+		desired.Annotations[reaper.GracePeriodAnnotation] = (time.Duration(mn.Spec.GracePeriodHours) * time.Hour).String()
+	}
+	if mn.Spec.Protected {
+		desired.Annotations[reaper.ProtectedAnnotation] = "true"
+	}
+
+	phase := nsmgrv1alpha1.PhaseActive
+	if err := ctrl.reconcileNamespace(mn, desired); err != nil {
+		reason := "NamespaceCreateFailed"
+		if errors.Is(err, kube.ErrWaitTerminatingTimeout) {
+			reason = "Terminating"
+		}
+		return ctrl.updateStatus(mn, nsmgrv1alpha1.PhaseFailed, expiresAt, err, reason)
+	}
+
+	return ctrl.updateStatus(mn, phase, expiresAt, nil, "")
+}
+
+// reconcileNamespace makes the real corev1.Namespace match desired:
+// creates it if it doesn't exist yet, waits out a previous delete if it's
+// stuck Terminating and then creates it, or - the common steady-state case -
+// patches its annotations in place if they've drifted from desired. That
+// last branch is what makes an extend or an AutoExtend rollover actually
+// take effect: both only ever update the ManagedNamespace's Status, and
+// without this patch the namespace's own expires_at annotation (the only
+// thing the reaper reads) would stay frozen at whatever was set on create.
+func (ctrl *ManagedNamespaceController) reconcileNamespace(mn *nsmgrv1alpha1.ManagedNamespace, desired *corev1.Namespace) error {
+	nsClient := ctrl.kubeClient.Clientset().CoreV1().Namespaces()
+
+	existing, err := nsClient.Get(context.Background(), desired.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = nsClient.Create(context.Background(), desired, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	if existing.Status.Phase == corev1.NamespaceTerminating {
+		waitTimeout := time.Duration(mn.Spec.WaitTerminatingSeconds) * time.Second
+		if waitTimeout <= 0 {
+			waitTimeout = 60 * time.Second
+		}
+		if err := ctrl.kubeClient.WaitForNamespaceGone(desired.Name, waitTimeout); err != nil {
+			return err
+		}
+		_, err = nsClient.Create(context.Background(), desired, metav1.CreateOptions{})
+		return err
+	}
+
+	return ctrl.patchAnnotations(existing, desired.Annotations)
+}
+
+// patchAnnotations merge-patches existing's annotations up to match want,
+// skipping the API call entirely when they already match. It only ever adds
+// or overwrites the keys in want - annotations existing carries that aren't
+// in want (kubectl-applied ones, say) are left alone.
+func (ctrl *ManagedNamespaceController) patchAnnotations(existing *corev1.Namespace, want map[string]string) error {
+	needsPatch := false
+	for k, v := range want {
+		if existing.Annotations[k] != v {
+			needsPatch = true
+			break
+		}
+	}
+	if !needsPatch {
+		return nil
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": want},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = ctrl.kubeClient.Clientset().CoreV1().Namespaces().Patch(context.Background(), existing.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+func (ctrl *ManagedNamespaceController) updateStatus(mn *nsmgrv1alpha1.ManagedNamespace, phase nsmgrv1alpha1.ManagedNamespacePhase, expiresAt time.Time, reconcileErr error, reason string) error {
+	mn.Status.Phase = phase
+	mn.Status.ExpiresAt = metav1.NewTime(expiresAt)
+
+	condition := nsmgrv1alpha1.Condition{
+		Type:               "Reconciled",
+		Status:             "True",
+		LastTransitionTime: metav1.Now(),
+	}
+	if reconcileErr != nil {
+		condition.Status = "False"
+		condition.Reason = reason
+		condition.Message = reconcileErr.Error()
+	}
+	mn.Status.Conditions = []nsmgrv1alpha1.Condition{condition}
+
+	_, err := ctrl.kubeClient.UpdateManagedNamespaceStatus(mn)
+	return err
+}
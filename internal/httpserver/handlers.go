@@ -2,34 +2,72 @@ package httpserver
 
 import (
 	"encoding/json"
+	"log"
 	"net/http"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	nsmgrv1alpha1 "namespace-manager/internal/apis/nsmgr/v1alpha1"
+	"namespace-manager/internal/auth"
+	"namespace-manager/internal/kube"
 )
 
+// identity returns the authenticated user and group list for an audit log
+// entry, falling back to an anonymous placeholder when no auth middleware
+// is configured (local development).
+func identity(r *http.Request) (string, []string) {
+	if user, ok := auth.UserFromContext(r.Context()); ok {
+		return user.Username, user.Groups
+	}
+	return "anonymous", nil
+}
+
+// toNamespaceInfo projects a ManagedNamespace CR onto the NamespaceInfo
+// shape the API returns, computing the remaining TTL from status.expiresAt.
+func toNamespaceInfo(mn nsmgrv1alpha1.ManagedNamespace) kube.NamespaceInfo {
+	var ttl int
+	if remaining := time.Until(mn.Status.ExpiresAt.Time); remaining > 0 {
+		ttl = int(remaining.Hours())
+	}
+	return kube.NamespaceInfo{
+		Name:          mn.Spec.Name,
+		Owner:         mn.Spec.Owner,
+		Team:          mn.Spec.Team,
+		CreatedAt:     mn.CreationTimestamp.Time,
+		ExpiresAt:     mn.Status.ExpiresAt.Time,
+		TTL:           ttl,
+		Phase:         string(mn.Status.Phase),
+		FailureReason: mn.ReconcileFailureReason(),
+	}
+}
+
+// defaultWaitTerminatingSeconds is how long the controller waits for a
+// same-named namespace stuck Terminating to disappear before retrying
+// creation, when the caller doesn't specify wait_terminating_seconds.
+const defaultWaitTerminatingSeconds = 60
+
 // CreateNamespaceRequest represents the JSON request body for creating a namespace
 type CreateNamespaceRequest struct {
 	Name  string `json:"name"`  // Namespace name
 	TTL   int    `json:"ttl"`   // Time to live in hours
 	Owner string `json:"owner"` // Owner name
 	Team  string `json:"team"`  // Team name
+	// WaitTerminatingSeconds bounds how long to wait for a same-named
+	// namespace stuck Terminating from a previous delete before retrying
+	// creation. Defaults to 60 if unset.
+	WaitTerminatingSeconds int `json:"wait_terminating_seconds"`
 }
 
-type DeleteNamespaceRequest struct {
-	Name string `json:"name"`
-}	
-
-type NamespaceResponse struct {
-	Message string `json:"message"`
-	Name    string `json:"name"`
+// ExtendNamespaceRequest represents the JSON request body for extending a
+// namespace's TTL. The namespace name itself comes from the path
+// (POST /api/v1/namespaces/{name}:extend), not the body.
+type ExtendNamespaceRequest struct {
+	AdditionalHours int `json:"additional_hours"`
 }
 
+// HandleCreateNamespaceRequest serves POST /api/v1/namespaces.
 func (s *Server) HandleCreateNamespaceRequest(w http.ResponseWriter, r *http.Request) {
-	// Only allow POST method
-	if r.Method != http.MethodPost {
-		w.WriteHeader(http.StatusMethodNotAllowed)
-		w.Write([]byte("Method not allowed. Use POST"))
-		return
-	}
-
 	// Parse JSON request body
 	var req CreateNamespaceRequest
 	err := json.NewDecoder(r.Body).Decode(&req)
@@ -60,14 +98,74 @@ func (s *Server) HandleCreateNamespaceRequest(w http.ResponseWriter, r *http.Req
 		w.Write([]byte("TTL must be greater than 0"))
 		return
 	}
+	if req.WaitTerminatingSeconds <= 0 {
+		req.WaitTerminatingSeconds = defaultWaitTerminatingSeconds
+	}
+
+	user, groups := identity(r)
+	if s.policyStore != nil {
+		policy := s.policyStore.Current()
+		if !policy.AllowedTeam(req.Team, groups) {
+			auth.Audit(auth.AuditEvent{User: user, Groups: groups, Action: "create", Resource: req.Name, Team: req.Team, Decision: "deny", Reason: "team not allowed for caller's groups"})
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("Not authorized to create namespaces for team " + req.Team))
+			return
+		}
+		if cap, ok := policy.TTLCap(req.Team); ok && req.TTL > cap {
+			auth.Audit(auth.AuditEvent{User: user, Groups: groups, Action: "create", Resource: req.Name, Team: req.Team, Decision: "deny", Reason: "ttl exceeds team cap"})
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("TTL exceeds the cap for team " + req.Team))
+			return
+		}
+	}
 
-	// Create the namespace
-	err = s.kubeClient.CreateNamespace(req.Name, req.TTL, req.Owner, req.Team)
+	// Idempotency: if a ManagedNamespace with this name already exists,
+	// don't error - either report it back (same owner, so this is a retry
+	// of an earlier request) or refuse (different owner trying to claim
+	// someone else's namespace).
+	if existing, err := s.kubeClient.GetManagedNamespace(req.Name); err == nil {
+		if existing.Spec.Owner != req.Owner {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte("Namespace " + req.Name + " already exists with a different owner"))
+			return
+		}
+
+		info, err := s.kubeClient.GetNamespaceInfo(req.Name)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(info)
+		return
+	} else if !apierrors.IsNotFound(err) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	// Create a ManagedNamespace custom resource - the managednamespace
+	// controller reconciles it into an actual corev1.Namespace
+	// asynchronously, so users get kubectl get managednamespaces
+	// visibility and RBAC on the CRD instead of on core namespaces.
+	_, err = s.kubeClient.CreateManagedNamespace(&nsmgrv1alpha1.ManagedNamespace{
+		Spec: nsmgrv1alpha1.ManagedNamespaceSpec{
+			Name:                   req.Name,
+			TTL:                    req.TTL,
+			Owner:                  req.Owner,
+			Team:                   req.Team,
+			WaitTerminatingSeconds: req.WaitTerminatingSeconds,
+		},
+	})
 	if err != nil {
+		auth.Audit(auth.AuditEvent{User: user, Groups: groups, Action: "create", Resource: req.Name, Team: req.Team, Decision: "error", Reason: err.Error()})
 		w.WriteHeader(http.StatusInternalServerError)
 		w.Write([]byte(err.Error()))
 		return
 	}
+	auth.Audit(auth.AuditEvent{User: user, Groups: groups, Action: "create", Resource: req.Name, Team: req.Team, Decision: "allow"})
 
 	// Return success response
 	w.Header().Set("Content-Type", "application/json")
@@ -78,61 +176,252 @@ func (s *Server) HandleCreateNamespaceRequest(w http.ResponseWriter, r *http.Req
 	})
 }
 
-func (s *Server) HandleDeleteNamespaceRequest(w http.ResponseWriter, r *http.Request) {
-	// Only allow DELETE method
-	if r.Method != http.MethodDelete {
-		w.WriteHeader(http.StatusMethodNotAllowed)
-		w.Write([]byte("Method not allowed. Use DELETE"))
+// HandleGetNamespaceRequest serves GET /api/v1/namespaces/{name}.
+func (s *Server) HandleGetNamespaceRequest(w http.ResponseWriter, r *http.Request) {
+	name := Param(r, "name")
+
+	info, err := s.kubeClient.GetNamespaceInfo(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("Namespace not found: " + name))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
 		return
 	}
 
-	// Parse JSON request body
-	var req DeleteNamespaceRequest
-	err := json.NewDecoder(r.Body).Decode(&req)
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("Invalid JSON: " + err.Error()))
+	w.Header().Set("Content-Type", "application/json")
+	if info.Phase == string(nsmgrv1alpha1.PhaseFailed) {
+		// Creation is async via the CRD, so this is the only place a client
+		// ever learns it permanently failed - a plain 200 here would hide
+		// that the namespace they asked for doesn't actually exist.
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(info)
 		return
 	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(info)
+}
 
-	// Delete the namespace
-	err = s.kubeClient.DeleteNamespace(req.Name)
+// HandleDeleteNamespaceRequest serves DELETE /api/v1/namespaces/{name}.
+func (s *Server) HandleDeleteNamespaceRequest(w http.ResponseWriter, r *http.Request) {
+	name := Param(r, "name")
+
+	user, groups := identity(r)
+	if s.policyStore != nil {
+		info, err := s.kubeClient.GetNamespaceInfo(name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte("Namespace not found: " + name))
+				return
+			}
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		if info.Owner != user && !s.policyStore.Current().IsTeamAdmin(info.Team, groups) {
+			auth.Audit(auth.AuditEvent{User: user, Groups: groups, Action: "delete", Resource: name, Team: info.Team, Decision: "deny", Reason: "caller is neither owner nor team admin"})
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("Not authorized to delete namespace " + name))
+			return
+		}
+	}
+
+	// Delete the ManagedNamespace CR; Kubernetes garbage-collects the
+	// underlying corev1.Namespace via the owner reference the controller
+	// set on it.
+	err := s.kubeClient.DeleteManagedNamespace(name)
 	if err != nil {
+		auth.Audit(auth.AuditEvent{User: user, Groups: groups, Action: "delete", Resource: name, Decision: "error", Reason: err.Error()})
 		w.WriteHeader(http.StatusInternalServerError)
 		w.Write([]byte(err.Error()))
 		return
 	}
+	auth.Audit(auth.AuditEvent{User: user, Groups: groups, Action: "delete", Resource: name, Decision: "allow"})
 
 	// Return success response
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]string{
 		"message": "Namespace deleted successfully",
-		"name":    req.Name,
+		"name":    name,
 	})
 }
 
+// listPageSize bounds how many ManagedNamespaces HandleListNamespacesRequest
+// fetches per page, so a large cluster is streamed rather than buffered.
+const listPageSize = 100
+
+// HandleListNamespacesRequest serves GET /api/v1/namespaces. It streams
+// results page-by-page (via ListOptions.Limit/Continue) instead of loading
+// every ManagedNamespace into memory at once, and - if watch=true - instead
+// streams lifecycle events as they happen as newline-delimited JSON, so a
+// UI can subscribe rather than poll.
 func (s *Server) HandleListNamespacesRequest(w http.ResponseWriter, r *http.Request) {
-	// Only allow GET method
-	if r.Method != http.MethodGet {
-		w.WriteHeader(http.StatusMethodNotAllowed)
-		w.Write([]byte("Method not allowed. Use GET"))
+	owner := r.URL.Query().Get("owner")
+
+	if r.URL.Query().Get("watch") == "true" {
+		s.streamNamespaceWatch(w, r, owner)
 		return
 	}
 
-	// Get owner from query parameter (optional - empty string means list all)
-	owner := r.URL.Query().Get("owner")
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("["))
 
-	// List the namespaces
-	namespaces, err := s.kubeClient.ListNamespaces(owner)
+	first := true
+	cont := ""
+	for {
+		page, next, err := s.kubeClient.ListManagedNamespacesPage(listPageSize, cont)
+		if err != nil {
+			// Headers and part of the body may already be written, so
+			// there's no clean way to turn this into a 500 - log it and
+			// stop the stream instead.
+			log.Printf("httpserver: listing namespaces page: %v", err)
+			break
+		}
+
+		for _, mn := range page {
+			if owner != "" && mn.Spec.Owner != owner {
+				continue
+			}
+			if !first {
+				w.Write([]byte(","))
+			}
+			first = false
+			encoder.Encode(toNamespaceInfo(mn))
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		if next == "" {
+			break
+		}
+		cont = next
+	}
+	w.Write([]byte("]"))
+}
+
+// NamespaceWatchEvent is one line of the ?watch=true newline-delimited JSON
+// stream: a ManagedNamespace lifecycle event and the namespace it concerns.
+type NamespaceWatchEvent struct {
+	Type      string             `json:"type"` // "ADDED", "MODIFIED", or "DELETED"
+	Namespace kube.NamespaceInfo `json:"namespace"`
+}
+
+// streamNamespaceWatch streams ManagedNamespace lifecycle events as
+// newline-delimited JSON until the client disconnects.
+func (s *Server) streamNamespaceWatch(w http.ResponseWriter, r *http.Request, owner string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("streaming not supported"))
+		return
+	}
+
+	events, err := s.kubeClient.WatchManagedNamespaces(r.Context())
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		w.Write([]byte(err.Error()))
 		return
 	}
 
-	// Return success response
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+
+	for event := range events {
+		if owner != "" && event.Object.Spec.Owner != owner {
+			continue
+		}
+		if err := encoder.Encode(NamespaceWatchEvent{Type: string(event.Type), Namespace: toNamespaceInfo(*event.Object)}); err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// HandleExtendNamespaceRequest serves POST /api/v1/namespaces/{name}:extend,
+// pushing a namespace's expires_at forward by additional_hours, capped by
+// the server's per-team extension policy if one is configured.
+func (s *Server) HandleExtendNamespaceRequest(w http.ResponseWriter, r *http.Request) {
+	name := Param(r, "name")
+
+	var req ExtendNamespaceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Invalid JSON: " + err.Error()))
+		return
+	}
+	if req.AdditionalHours <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("additional_hours must be greater than 0"))
+		return
+	}
+
+	info, err := s.kubeClient.GetNamespaceInfo(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("Namespace not found: " + name))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	user, groups := identity(r)
+	if max, ok := s.extensionPolicy.MaxExtensionHours(info.Team); ok && req.AdditionalHours > max {
+		auth.Audit(auth.AuditEvent{User: user, Groups: groups, Action: "extend", Resource: name, Team: info.Team, Decision: "deny", Reason: "additional_hours exceeds team cap"})
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("additional_hours exceeds the max extension for team " + info.Team))
+		return
+	}
+
+	mn, err := s.kubeClient.ExtendNamespace(name, req.AdditionalHours, user)
+	if err != nil {
+		auth.Audit(auth.AuditEvent{User: user, Groups: groups, Action: "extend", Resource: name, Team: info.Team, Decision: "error", Reason: err.Error()})
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	auth.Audit(auth.AuditEvent{User: user, Groups: groups, Action: "extend", Resource: name, Team: info.Team, Decision: "allow"})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(toNamespaceInfo(*mn))
+}
+
+// HandleExpiringNamespacesRequest serves GET /api/v1/namespaces:expiring?within=24h,
+// backed by the reaper's in-memory index rather than a live API call, so it
+// only reports namespaces the reaper actually knows about.
+func (s *Server) HandleExpiringNamespacesRequest(w http.ResponseWriter, r *http.Request) {
+	within := 24 * time.Hour
+	if raw := r.URL.Query().Get("within"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("Invalid within duration: " + err.Error()))
+			return
+		}
+		within = parsed
+	}
+
+	namespaces := []kube.NamespaceInfo{}
+	if s.expiryLister != nil {
+		namespaces = s.expiryLister.ExpiringWithin(within)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(namespaces)
-}
\ No newline at end of file
+}
@@ -0,0 +1,141 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// HandleOpenAPI serves a static OpenAPI 3 document describing the
+// /api/v1/namespaces endpoints, so Swagger UI (served at /docs) and any
+// codegen clients have something to introspect without hand-written docs
+// drifting out of sync.
+func (s *Server) HandleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(openAPISpec)
+}
+
+// HandleDocs serves a minimal Swagger UI page pointed at /openapi.json.
+func (s *Server) HandleDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(swaggerUIPage))
+}
+
+var openAPISpec = map[string]interface{}{
+	"openapi": "3.0.3",
+	"info": map[string]interface{}{
+		"title":   "namespace-manager API",
+		"version": "v1",
+	},
+	"paths": map[string]interface{}{
+		"/api/v1/namespaces": map[string]interface{}{
+			"get": map[string]interface{}{
+				"summary": "List managed namespaces",
+				"parameters": []map[string]interface{}{
+					{"name": "owner", "in": "query", "schema": map[string]string{"type": "string"}, "description": "Filter by owner"},
+					{"name": "watch", "in": "query", "schema": map[string]string{"type": "boolean"}, "description": "Stream lifecycle events as newline-delimited JSON instead of returning a snapshot"},
+				},
+				"responses": map[string]interface{}{
+					"200": map[string]interface{}{"description": "A JSON array of namespaces, or an NDJSON event stream if watch=true"},
+				},
+			},
+			"post": map[string]interface{}{
+				"summary": "Create a namespace",
+				"requestBody": map[string]interface{}{
+					"content": map[string]interface{}{
+						"application/json": map[string]interface{}{
+							"schema": map[string]interface{}{"$ref": "#/components/schemas/CreateNamespaceRequest"},
+						},
+					},
+				},
+				"responses": map[string]interface{}{
+					"201": map[string]interface{}{"description": "Created"},
+					"200": map[string]interface{}{"description": "Already exists with the same owner (idempotent retry)"},
+					"409": map[string]interface{}{"description": "Already exists with a different owner"},
+				},
+			},
+		},
+		"/api/v1/namespaces/{name}": map[string]interface{}{
+			"get": map[string]interface{}{
+				"summary":    "Get a namespace",
+				"parameters": []map[string]interface{}{{"name": "name", "in": "path", "required": true, "schema": map[string]string{"type": "string"}}},
+				"responses": map[string]interface{}{
+					"200": map[string]interface{}{"description": "OK"},
+					"404": map[string]interface{}{"description": "Not found"},
+					"409": map[string]interface{}{"description": "Namespace creation permanently failed; see phase/failure_reason in the body"},
+				},
+			},
+			"delete": map[string]interface{}{
+				"summary":    "Delete a namespace",
+				"parameters": []map[string]interface{}{{"name": "name", "in": "path", "required": true, "schema": map[string]string{"type": "string"}}},
+				"responses": map[string]interface{}{
+					"200": map[string]interface{}{"description": "Deleted"},
+					"403": map[string]interface{}{"description": "Caller is neither owner nor team admin"},
+					"404": map[string]interface{}{"description": "Not found"},
+				},
+			},
+		},
+		"/api/v1/namespaces/{name}:extend": map[string]interface{}{
+			"post": map[string]interface{}{
+				"summary":    "Extend a namespace's TTL",
+				"parameters": []map[string]interface{}{{"name": "name", "in": "path", "required": true, "schema": map[string]string{"type": "string"}}},
+				"requestBody": map[string]interface{}{
+					"content": map[string]interface{}{
+						"application/json": map[string]interface{}{
+							"schema": map[string]interface{}{"$ref": "#/components/schemas/ExtendNamespaceRequest"},
+						},
+					},
+				},
+				"responses": map[string]interface{}{
+					"200": map[string]interface{}{"description": "OK"},
+					"400": map[string]interface{}{"description": "additional_hours exceeds the team's cap"},
+					"404": map[string]interface{}{"description": "Not found"},
+				},
+			},
+		},
+	},
+	"components": map[string]interface{}{
+		"schemas": map[string]interface{}{
+			"CreateNamespaceRequest": map[string]interface{}{
+				"type":     "object",
+				"required": []string{"name", "ttl", "owner", "team"},
+				"properties": map[string]interface{}{
+					"name":                     map[string]string{"type": "string"},
+					"ttl":                      map[string]string{"type": "integer"},
+					"owner":                    map[string]string{"type": "string"},
+					"team":                     map[string]string{"type": "string"},
+					"wait_terminating_seconds": map[string]string{"type": "integer"},
+				},
+			},
+			"ExtendNamespaceRequest": map[string]interface{}{
+				"type":     "object",
+				"required": []string{"additional_hours"},
+				"properties": map[string]interface{}{
+					"additional_hours": map[string]string{"type": "integer"},
+				},
+			},
+		},
+	},
+}
+
+const swaggerUIPage = `<!DOCTYPE html>
+<html>
+<head>
+  <title>namespace-manager API docs</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = () => {
+      SwaggerUIBundle({
+        url: "/openapi.json",
+        dom_id: "#swagger-ui",
+      });
+    };
+  </script>
+</body>
+</html>
+`
@@ -0,0 +1,134 @@
+package httpserver
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type contextKey int
+
+const paramsContextKey contextKey = iota
+
+type routeParams map[string]string
+
+// Param returns the value of a named path parameter captured by the router
+// for the current request, e.g. "name" for a route registered as
+// "/api/v1/namespaces/{name}". Returns "" if the route wasn't registered
+// with that parameter.
+func Param(r *http.Request, name string) string {
+	params, _ := r.Context().Value(paramsContextKey).(routeParams)
+	return params[name]
+}
+
+// segment is one "/"-separated piece of a route pattern: either a literal
+// to match exactly, or a "{param}" capture, optionally followed by a
+// literal suffix for action-style routes like "/namespaces/{name}:extend".
+type segment struct {
+	literal string
+	param   string
+	suffix  string
+}
+
+func parsePattern(pattern string) []segment {
+	parts := strings.Split(strings.Trim(pattern, "/"), "/")
+	segments := make([]segment, 0, len(parts))
+	for _, part := range parts {
+		if strings.HasPrefix(part, "{") {
+			end := strings.Index(part, "}")
+			segments = append(segments, segment{param: part[1:end], suffix: part[end+1:]})
+			continue
+		}
+		segments = append(segments, segment{literal: part})
+	}
+	return segments
+}
+
+// match reports whether value satisfies this segment, returning the
+// captured parameter value (if any).
+func (s segment) match(value string) (string, bool) {
+	if s.param == "" {
+		return "", value == s.literal
+	}
+	if s.suffix == "" {
+		return value, true
+	}
+	if !strings.HasSuffix(value, s.suffix) || len(value) <= len(s.suffix) {
+		return "", false
+	}
+	return value[:len(value)-len(s.suffix)], true
+}
+
+type route struct {
+	method   string
+	segments []segment
+	handler  http.Handler
+}
+
+func (rt route) matchPath(parts []string) (routeParams, bool) {
+	if len(parts) != len(rt.segments) {
+		return nil, false
+	}
+	params := routeParams{}
+	for i, seg := range rt.segments {
+		value, ok := seg.match(parts[i])
+		if !ok {
+			return nil, false
+		}
+		if seg.param != "" {
+			params[seg.param] = value
+		}
+	}
+	return params, true
+}
+
+// Router is a minimal path-param-aware, method-dispatching HTTP router.
+// namespace-manager doesn't otherwise depend on a routing library (chi,
+// gorilla/mux, ...), so this hand-rolls just enough of one - literal and
+// "{param}" segments plus per-method dispatch - rather than pull one in.
+type Router struct {
+	routes []route
+}
+
+// NewRouter creates an empty Router.
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// Handle registers handler for method+pattern. Segments of pattern wrapped
+// in "{}" capture a path parameter, retrievable via Param(r, name) from
+// inside handler.
+func (rt *Router) Handle(method, pattern string, handler http.Handler) {
+	rt.routes = append(rt.routes, route{method: method, segments: parsePattern(pattern), handler: handler})
+}
+
+// HandleFunc is the http.HandlerFunc equivalent of Handle.
+func (rt *Router) HandleFunc(method, pattern string, handler http.HandlerFunc) {
+	rt.Handle(method, pattern, handler)
+}
+
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	pathMatched := false
+	for _, rte := range rt.routes {
+		params, ok := rte.matchPath(parts)
+		if !ok {
+			continue
+		}
+		if rte.method != r.Method {
+			pathMatched = true
+			continue
+		}
+
+		ctx := context.WithValue(r.Context(), paramsContextKey, params)
+		rte.handler.ServeHTTP(w, r.WithContext(ctx))
+		return
+	}
+
+	if pathMatched {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	http.NotFound(w, r)
+}
@@ -0,0 +1,60 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouterPathAndSuffixMatching(t *testing.T) {
+	rt := NewRouter()
+	rt.HandleFunc(http.MethodGet, "/api/v1/namespaces", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Route", "list")
+	})
+	rt.HandleFunc(http.MethodGet, "/api/v1/namespaces:expiring", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Route", "expiring")
+	})
+	rt.HandleFunc(http.MethodGet, "/api/v1/namespaces/{name}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Route", "get")
+		w.Header().Set("X-Name", Param(r, "name"))
+	})
+	rt.HandleFunc(http.MethodPost, "/api/v1/namespaces/{name}:extend", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Route", "extend")
+		w.Header().Set("X-Name", Param(r, "name"))
+	})
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+		wantRoute  string
+		wantName   string
+	}{
+		{name: "list", method: http.MethodGet, path: "/api/v1/namespaces", wantStatus: http.StatusOK, wantRoute: "list"},
+		{name: "expiring action segment doesn't collide with {name}", method: http.MethodGet, path: "/api/v1/namespaces:expiring", wantStatus: http.StatusOK, wantRoute: "expiring"},
+		{name: "param capture", method: http.MethodGet, path: "/api/v1/namespaces/dev-123", wantStatus: http.StatusOK, wantRoute: "get", wantName: "dev-123"},
+		{name: "suffix action route strips suffix from capture", method: http.MethodPost, path: "/api/v1/namespaces/dev-123:extend", wantStatus: http.StatusOK, wantRoute: "extend", wantName: "dev-123"},
+		{name: "name that merely contains the suffix text isn't treated as an extend", method: http.MethodGet, path: "/api/v1/namespaces/dev-123:extend", wantStatus: http.StatusOK, wantRoute: "get", wantName: "dev-123:extend"},
+		{name: "unknown path is 404", method: http.MethodGet, path: "/api/v1/widgets", wantStatus: http.StatusNotFound},
+		{name: "known path, wrong method is 405", method: http.MethodDelete, path: "/api/v1/namespaces", wantStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			rec := httptest.NewRecorder()
+			rt.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantRoute != "" && rec.Header().Get("X-Route") != tt.wantRoute {
+				t.Errorf("route = %q, want %q", rec.Header().Get("X-Route"), tt.wantRoute)
+			}
+			if tt.wantName != "" && rec.Header().Get("X-Name") != tt.wantName {
+				t.Errorf("captured name = %q, want %q", rec.Header().Get("X-Name"), tt.wantName)
+			}
+		})
+	}
+}
@@ -2,39 +2,100 @@ package httpserver
 
 import (
 	"net/http"
+	"time"
+
+	"namespace-manager/internal/auth"
+	"namespace-manager/internal/config"
 	"namespace-manager/internal/kube"
 )
 
+// ExpiryLister is the subset of the reaper's index the httpserver needs to
+// serve /api/v1/namespaces:expiring. It's an interface (rather than
+// importing *reaper.Reaper directly) so the server doesn't have to know the
+// reaper is even running - it just reports nothing if no lister was set.
+type ExpiryLister interface {
+	ExpiringWithin(d time.Duration) []kube.NamespaceInfo
+}
+
 // Server wraps the standard http.Server and adds routing capabilities
 type Server struct {
-	server    *http.Server  // The underlying HTTP server from Go's standard library
-	mux       *http.ServeMux // Mux (multiplexer) - routes incoming requests to the right handler
-	kubeClient *kube.Client  // Kubernetes client to perform namespace operations
+	server       *http.Server // The underlying HTTP server from Go's standard library
+	router       *Router      // Router - dispatches incoming requests by method+path, capturing path params
+	kubeClient   *kube.Client // Kubernetes client to perform namespace operations
+	expiryLister ExpiryLister // Optional: set via SetExpiryLister if the reaper is running
+
+	extensionPolicy *config.ExtensionPolicy // Optional: set via SetExtensionPolicy to cap /extend calls per team
+
+	authMiddleware func(http.Handler) http.Handler // Optional: set via SetAuthMiddleware to require/validate bearer tokens
+	policyStore    *auth.PolicyStore               // Optional: set via SetPolicyStore for group->team/TTL-cap enforcement
 }
 
 // NewServer creates a new Server instance
-// The mux is like a traffic director - it looks at the URL path and sends
-// the request to the correct handler function
+// The router is like a traffic director - it looks at the method and URL
+// path and sends the request to the correct handler function
 // kubeClient is the Kubernetes client that will be used by handlers to interact with Kubernetes
 func NewServer(addr string, kubeClient *kube.Client) *Server {
-	// Create a new mux (router) that will handle routing requests
-	mux := http.NewServeMux()
-	
+	router := NewRouter()
+
 	return &Server{
 		server: &http.Server{
-			Addr:    addr,        // Address to listen on (e.g., ":8080")
-			Handler: mux,         // Tell the server to use our mux to route requests
+			Addr:    addr,   // Address to listen on (e.g., ":8080")
+			Handler: router, // Tell the server to use our router to route requests
 		},
-		mux:       mux,        // Store the mux so we can register routes on it later
+		router:     router,     // Store the router so we can register routes on it later
 		kubeClient: kubeClient, // Store the Kubernetes client so handlers can use it
 	}
 }
 
-// RegisterRoute connects a URL path to a handler function
-// When someone visits the path (e.g., "/"), the handler function will be called
-// Example: RegisterRoute("/", handleRoot) means "when someone goes to /, call handleRoot"
-func (s *Server) RegisterRoute(path string, handler http.HandlerFunc) {
-	s.mux.HandleFunc(path, handler)
+// SetExpiryLister wires up the reaper so HandleExpiringNamespacesRequest has
+// something to query. Safe to leave unset if the reaper isn't running -
+// the handler just reports an empty list.
+func (s *Server) SetExpiryLister(lister ExpiryLister) {
+	s.expiryLister = lister
+}
+
+// SetExtensionPolicy wires up the per-team max-extension policy that
+// HandleExtendNamespaceRequest enforces. Safe to leave unset - extensions
+// are then uncapped.
+func (s *Server) SetExtensionPolicy(policy *config.ExtensionPolicy) {
+	s.extensionPolicy = policy
+}
+
+// SetAuthMiddleware wires up request authentication. Once set, every route
+// registered afterwards via Handle requires a valid bearer token. Safe to
+// leave unset for local development - routes are then unauthenticated.
+func (s *Server) SetAuthMiddleware(middleware func(http.Handler) http.Handler) {
+	s.authMiddleware = middleware
+}
+
+// SetPolicyStore wires up the group->team/TTL-cap policy that
+// HandleCreateNamespaceRequest and HandleDeleteNamespaceRequest enforce
+// whenever it is set. Without auth middleware every caller is "anonymous"
+// with no groups, so the policy then denies every create.
+func (s *Server) SetPolicyStore(store *auth.PolicyStore) {
+	s.policyStore = store
+}
+
+// Handle connects a method+path pattern to a handler function. Pattern
+// segments wrapped in "{}" capture a path parameter, retrievable from
+// inside handler via Param(r, name). If auth middleware has been
+// configured via SetAuthMiddleware, it wraps handler.
+// Example: Handle(http.MethodGet, "/api/v1/namespaces/{name}", h) means
+// "when someone GETs /api/v1/namespaces/foo, call h with Param(r, "name") == "foo"".
+func (s *Server) Handle(method, pattern string, handler http.HandlerFunc) {
+	if s.authMiddleware != nil {
+		s.router.Handle(method, pattern, s.authMiddleware(handler))
+		return
+	}
+	s.router.HandleFunc(method, pattern, handler)
+}
+
+// HandlePublic connects a method+path pattern to a handler function without
+// wrapping it in the auth middleware, even if one has been configured via
+// SetAuthMiddleware. Used for routes that must stay reachable without a
+// token, like the OpenAPI document and its docs UI.
+func (s *Server) HandlePublic(method, pattern string, handler http.HandlerFunc) {
+	s.router.HandleFunc(method, pattern, handler)
 }
 
 // ListenAndServe starts the HTTP server and begins listening for requests
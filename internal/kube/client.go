@@ -1,6 +1,7 @@
 package kube
 
 import (
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -8,7 +9,8 @@ import (
 
 // Client wraps the Kubernetes clientset to interact with the Kubernetes API
 type Client struct {
-	clientset *kubernetes.Clientset // The Kubernetes API client
+	clientset *kubernetes.Clientset  // The Kubernetes API client
+	dynamic   dynamic.Interface      // Generic client used for the ManagedNamespace CRD
 	config    *rest.Config           // Configuration for connecting to the cluster
 }
 
@@ -36,8 +38,31 @@ func NewClient() (*Client, error) {
 		return nil, err
 	}
 
+	// Create the dynamic client used for the ManagedNamespace CRD - we don't
+	// have a generated typed client for it, so we talk to the API server via
+	// unstructured objects instead.
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Client{
 		clientset: clientset,
+		dynamic:   dynamicClient,
 		config:    config,
 	}, nil
+}
+
+// Clientset returns the underlying Kubernetes clientset, for callers (such as
+// the reaper's shared informer factory) that need to talk to the API server
+// directly instead of going through Client's higher-level methods.
+func (c *Client) Clientset() *kubernetes.Clientset {
+	return c.clientset
+}
+
+// Dynamic returns the dynamic client used to operate on the ManagedNamespace
+// CRD, for callers (such as the managednamespace controller) that need to
+// watch or list it directly.
+func (c *Client) Dynamic() dynamic.Interface {
+	return c.dynamic
 }
\ No newline at end of file
@@ -0,0 +1,288 @@
+package kube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+
+	nsmgrv1alpha1 "namespace-manager/internal/apis/nsmgr/v1alpha1"
+)
+
+// CreateManagedNamespace creates a ManagedNamespace custom resource. It does
+// not create the underlying corev1.Namespace itself - the managednamespace
+// controller reconciles that asynchronously from the CR.
+func (c *Client) CreateManagedNamespace(mn *nsmgrv1alpha1.ManagedNamespace) (*nsmgrv1alpha1.ManagedNamespace, error) {
+	mn.TypeMeta = metav1.TypeMeta{APIVersion: nsmgrv1alpha1.SchemeGroupVersion.String(), Kind: nsmgrv1alpha1.Kind}
+	if mn.Name == "" {
+		mn.Name = mn.Spec.Name
+	}
+
+	obj, err := toUnstructured(mn)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := c.dynamic.Resource(nsmgrv1alpha1.Resource()).Create(context.Background(), obj, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return fromUnstructured(created)
+}
+
+// GetManagedNamespace fetches a ManagedNamespace by name.
+func (c *Client) GetManagedNamespace(name string) (*nsmgrv1alpha1.ManagedNamespace, error) {
+	obj, err := c.dynamic.Resource(nsmgrv1alpha1.Resource()).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return fromUnstructured(obj)
+}
+
+// ListManagedNamespaces lists all ManagedNamespace custom resources.
+func (c *Client) ListManagedNamespaces() ([]nsmgrv1alpha1.ManagedNamespace, error) {
+	list, err := c.dynamic.Resource(nsmgrv1alpha1.Resource()).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]nsmgrv1alpha1.ManagedNamespace, 0, len(list.Items))
+	for i := range list.Items {
+		mn, err := fromUnstructured(&list.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *mn)
+	}
+	return result, nil
+}
+
+// ListManagedNamespacesPage lists up to limit ManagedNamespace custom
+// resources, continuing from a previous page's continue token if cont is
+// non-empty. Callers that want every page loop until the returned continue
+// token is "", instead of ListManagedNamespaces buffering the whole list in
+// memory up front.
+func (c *Client) ListManagedNamespacesPage(limit int64, cont string) ([]nsmgrv1alpha1.ManagedNamespace, string, error) {
+	list, err := c.dynamic.Resource(nsmgrv1alpha1.Resource()).List(context.Background(), metav1.ListOptions{Limit: limit, Continue: cont})
+	if err != nil {
+		return nil, "", err
+	}
+
+	result := make([]nsmgrv1alpha1.ManagedNamespace, 0, len(list.Items))
+	for i := range list.Items {
+		mn, err := fromUnstructured(&list.Items[i])
+		if err != nil {
+			return nil, "", err
+		}
+		result = append(result, *mn)
+	}
+	return result, list.GetContinue(), nil
+}
+
+// ManagedNamespaceEvent is one watch event for a ManagedNamespace, decoded
+// from the underlying unstructured watch stream so callers never have to
+// deal with unstructured.Unstructured themselves.
+type ManagedNamespaceEvent struct {
+	Type   watch.EventType
+	Object *nsmgrv1alpha1.ManagedNamespace
+}
+
+// WatchManagedNamespaces watches ManagedNamespace custom resources and
+// streams decoded events on the returned channel until ctx is cancelled, at
+// which point the channel is closed. Intended for long-lived subscribers
+// like the /api/v1/namespaces?watch=true endpoint.
+func (c *Client) WatchManagedNamespaces(ctx context.Context) (<-chan ManagedNamespaceEvent, error) {
+	watcher, err := c.dynamic.Resource(nsmgrv1alpha1.Resource()).Watch(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan ManagedNamespaceEvent)
+	go func() {
+		defer close(events)
+		defer watcher.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.ResultChan():
+				if !ok {
+					return
+				}
+				u, ok := event.Object.(*unstructured.Unstructured)
+				if !ok {
+					continue
+				}
+				mn, err := fromUnstructured(u)
+				if err != nil {
+					continue
+				}
+				select {
+				case events <- ManagedNamespaceEvent{Type: event.Type, Object: mn}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return events, nil
+}
+
+// DeleteManagedNamespace deletes a ManagedNamespace custom resource. The
+// underlying corev1.Namespace is garbage-collected by Kubernetes via the
+// owner reference the controller sets on it.
+func (c *Client) DeleteManagedNamespace(name string) error {
+	return c.dynamic.Resource(nsmgrv1alpha1.Resource()).Delete(context.Background(), name, metav1.DeleteOptions{})
+}
+
+// UpdateManagedNamespaceStatus persists mn.Status via the status
+// subresource. mn must have been read from the API server (or already have
+// ResourceVersion/UID set) so the update can be applied to the right object.
+func (c *Client) UpdateManagedNamespaceStatus(mn *nsmgrv1alpha1.ManagedNamespace) (*nsmgrv1alpha1.ManagedNamespace, error) {
+	obj, err := toUnstructured(mn)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := c.dynamic.Resource(nsmgrv1alpha1.Resource()).UpdateStatus(context.Background(), obj, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return fromUnstructured(updated)
+}
+
+// jsonPatchOp is one operation in an RFC 6902 JSON Patch document.
+type jsonPatchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// maxExtendRetries bounds how many times ExtendNamespace retries a patch
+// whose resourceVersion test failed, so two callers racing to extend the
+// same namespace don't retry forever against each other.
+const maxExtendRetries = 5
+
+// ExtendNamespace pushes a ManagedNamespace's expires_at forward by
+// additionalHours and records the extension in its nsmgr.io/extension-history
+// annotation. by identifies the caller for the audit trail.
+//
+// The expires_at/lastExtendedAt status fields and the extension-history
+// annotation are applied via two JSON patches (status and metadata are
+// separate subresources) rather than a full object Update, so a concurrent
+// edit to any other field of the ManagedNamespace isn't clobbered. Each
+// patch leads with a "test" op on resourceVersion, so a second concurrent
+// extend can't blindly overwrite the first's addition: its test fails with a
+// Conflict, and it retries from a fresh read instead, compounding onto
+// whatever the first call landed.
+func (c *Client) ExtendNamespace(name string, additionalHours int, by string) (*nsmgrv1alpha1.ManagedNamespace, error) {
+	resource := c.dynamic.Resource(nsmgrv1alpha1.Resource())
+	now := time.Now()
+
+	for attempt := 0; ; attempt++ {
+		mn, err := c.GetManagedNamespace(name)
+		if err != nil {
+			return nil, err
+		}
+
+		newExpiresAt := mn.Status.ExpiresAt.Time.Add(time.Duration(additionalHours) * time.Hour)
+		statusPatch, err := json.Marshal([]jsonPatchOp{
+			{Op: "test", Path: "/metadata/resourceVersion", Value: mn.ResourceVersion},
+			{Op: "replace", Path: "/status/expiresAt", Value: newExpiresAt.Format(time.RFC3339)},
+			{Op: "replace", Path: "/status/lastExtendedAt", Value: now.Format(time.RFC3339)},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := resource.Patch(context.Background(), name, types.JSONPatchType, statusPatch, metav1.PatchOptions{}, "status"); err != nil {
+			if apierrors.IsConflict(err) && attempt < maxExtendRetries {
+				continue
+			}
+			return nil, fmt.Errorf("patching status for %q: %w", name, err)
+		}
+		break
+	}
+
+	for attempt := 0; ; attempt++ {
+		mn, err := c.GetManagedNamespace(name)
+		if err != nil {
+			return nil, err
+		}
+
+		var history []nsmgrv1alpha1.ExtensionRecord
+		rawHistory := mn.Annotations[nsmgrv1alpha1.ExtensionHistoryAnnotation]
+		if rawHistory != "" {
+			// Best-effort: an unparsable history shouldn't block the
+			// extension itself, just start a fresh trail.
+			_ = json.Unmarshal([]byte(rawHistory), &history)
+		}
+		history = append(history, nsmgrv1alpha1.ExtensionRecord{By: by, At: now, Hours: additionalHours})
+		historyJSON, err := json.Marshal(history)
+		if err != nil {
+			return nil, fmt.Errorf("marshaling extension history for %q: %w", name, err)
+		}
+
+		op := "replace"
+		if rawHistory == "" {
+			op = "add"
+		}
+		annotationsPatch, err := json.Marshal([]jsonPatchOp{
+			{Op: "test", Path: "/metadata/resourceVersion", Value: mn.ResourceVersion},
+			{Op: op, Path: "/metadata/annotations/" + jsonPatchEscape(nsmgrv1alpha1.ExtensionHistoryAnnotation), Value: string(historyJSON)},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		updated, err := resource.Patch(context.Background(), name, types.JSONPatchType, annotationsPatch, metav1.PatchOptions{})
+		if err != nil {
+			if apierrors.IsConflict(err) && attempt < maxExtendRetries {
+				continue
+			}
+			return nil, fmt.Errorf("patching annotations for %q: %w", name, err)
+		}
+		return fromUnstructured(updated)
+	}
+}
+
+// jsonPatchEscape escapes "/" and "~" per RFC 6902 so an annotation key
+// containing either can be used as a JSON Patch path segment.
+func jsonPatchEscape(s string) string {
+	escaped := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '~':
+			escaped = append(escaped, '~', '0')
+		case '/':
+			escaped = append(escaped, '~', '1')
+		default:
+			escaped = append(escaped, s[i])
+		}
+	}
+	return string(escaped)
+}
+
+func toUnstructured(mn *nsmgrv1alpha1.ManagedNamespace) (*unstructured.Unstructured, error) {
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(mn)
+	if err != nil {
+		return nil, fmt.Errorf("converting ManagedNamespace %q to unstructured: %w", mn.Name, err)
+	}
+	return &unstructured.Unstructured{Object: m}, nil
+}
+
+func fromUnstructured(obj *unstructured.Unstructured) (*nsmgrv1alpha1.ManagedNamespace, error) {
+	mn := &nsmgrv1alpha1.ManagedNamespace{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, mn); err != nil {
+		return nil, fmt.Errorf("converting unstructured to ManagedNamespace: %w", err)
+	}
+	return mn, nil
+}
@@ -1,12 +1,20 @@
 package kube
 
 import (
+	"context"
+	"errors"
 	"time"
-	"context" 
-	corev1 "k8s.io/api/core/v1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// ErrWaitTerminatingTimeout is returned when a namespace is still
+// Terminating after the caller's timeout elapses, so callers (like the
+// managednamespace controller) can record a distinct, machine-readable
+// reason instead of a generic error.
+var ErrWaitTerminatingTimeout = errors.New("namespace still terminating after timeout")
+
 // NamespaceInfo represents namespace information with TTL details
 type NamespaceInfo struct {
 	Name      string    `json:"name"`
@@ -15,25 +23,35 @@ type NamespaceInfo struct {
 	CreatedAt time.Time `json:"created_at"`
 	ExpiresAt time.Time `json:"expires_at"`
 	TTL       int       `json:"ttl"` // TTL in hours
+	// Phase mirrors the ManagedNamespace CR's status.phase. Without it, GET
+	// looked the same (200, a plausible expires_at) whether the namespace
+	// reconciled cleanly or its creation permanently failed.
+	Phase string `json:"phase,omitempty"`
+	// FailureReason is the Reconciled condition's reason when Phase is
+	// Failed - e.g. "Terminating" when the wait for a stuck-Terminating
+	// namespace timed out - so callers can learn why instead of guessing.
+	FailureReason string `json:"failure_reason,omitempty"`
 }
 
-// CreateNamespace creates a namespace with TTL, owner, and team annotations
-func (c *Client) CreateNamespace(name string, ttlHours int, owner string, team string) error {
-	namespace := &corev1.Namespace{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: name,
-			Annotations: map[string]string{
-				"owner": owner,
-				"team": team,
-				"expires_at": time.Now().Add(time.Duration(ttlHours) * time.Hour).Format(time.RFC3339),		// TTL in RFC3339 format for Kubernetes to parse
-			},
-		},
-	}
-	_, err := c.clientset.CoreV1().Namespaces().Create(context.Background(), namespace, metav1.CreateOptions{})
-	if err != nil {
-		return err
+// WaitForNamespaceGone polls every 500ms until name no longer exists or
+// timeout elapses, returning ErrWaitTerminatingTimeout in the latter case.
+func (c *Client) WaitForNamespaceGone(name string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	for {
+		_, err := c.clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ErrWaitTerminatingTimeout
+		default:
+			time.Sleep(500 * time.Millisecond)
+		}
 	}
-	return nil
 }
 
 func (c *Client) DeleteNamespace(name string) error {
@@ -68,63 +86,27 @@ func (c *Client) DeleteNamespace(name string) error {
 		}
 	}
 }
- // ListNamespaces lists all namespaces, optionally filtered by owner
- func (c *Client) ListNamespaces(owner string) ([]NamespaceInfo, error) {
-	namespaces, err := c.clientset.CoreV1().Namespaces().List(context.Background(), metav1.ListOptions{})
+// GetNamespaceInfo returns full namespace information for a single
+// namespace, read from its ManagedNamespace CR.
+func (c *Client) GetNamespaceInfo(name string) (*NamespaceInfo, error) {
+	mn, err := c.GetManagedNamespace(name)
 	if err != nil {
 		return nil, err
 	}
-	
-	// Convert Kubernetes namespaces to NamespaceInfo
-	var result []NamespaceInfo
-	for _, ns := range namespaces.Items {
-		// Extract annotations
-		annotations := ns.ObjectMeta.Annotations
-		nsOwner := annotations["owner"]
-		team := annotations["team"]
-		expiresAtStr := annotations["expires_at"]
-		
-		// Filter by owner if specified
-		if owner != "" && nsOwner != owner {
-			continue
-		}
-		
-		// Parse expires_at to calculate TTL
-		var expiresAt time.Time
-		var ttl int
-		if expiresAtStr != "" {
-			expiresAt, err = time.Parse(time.RFC3339, expiresAtStr)
-			if err == nil {
-				// Calculate remaining TTL in hours
-				remaining := time.Until(expiresAt)
-				if remaining > 0 {
-					ttl = int(remaining.Hours())
-				}
-			}
-		}
-		
-		// Create NamespaceInfo
-		info := NamespaceInfo{
-			Name:      ns.ObjectMeta.Name,
-			Owner:     nsOwner,
-			Team:      team,
-			CreatedAt: ns.ObjectMeta.CreationTimestamp.Time,
-			ExpiresAt: expiresAt,
-			TTL:       ttl,
-		}
-		
-		result = append(result, info)
-	}
-	
-	return result, nil
-}
 
-// // GetNamespaceTTL returns the remaining TTL for a namespace
-// func (c *Client) GetNamespaceTTL(name string) (int, error) {
-// 	// Implementation here
-// }
+	var ttl int
+	if remaining := time.Until(mn.Status.ExpiresAt.Time); remaining > 0 {
+		ttl = int(remaining.Hours())
+	}
 
-// // GetNamespaceInfo returns full namespace information
-// func (c *Client) GetNamespaceInfo(name string) (*NamespaceInfo, error) {
-// 	// Implementation here
-// }
\ No newline at end of file
+	return &NamespaceInfo{
+		Name:          mn.Spec.Name,
+		Owner:         mn.Spec.Owner,
+		Team:          mn.Spec.Team,
+		CreatedAt:     mn.CreationTimestamp.Time,
+		ExpiresAt:     mn.Status.ExpiresAt.Time,
+		TTL:           ttl,
+		Phase:         string(mn.Status.Phase),
+		FailureReason: mn.ReconcileFailureReason(),
+	}, nil
+}
\ No newline at end of file
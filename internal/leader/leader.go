@@ -0,0 +1,82 @@
+// Package leader provides a small leader-election helper shared by every
+// background controller in namespace-manager (the reaper, the
+// managednamespace controller, ...) so that running more than one replica
+// doesn't cause them to race each other reconciling the same resources.
+package leader
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+const (
+	leaseNamespace = "kube-system"
+
+	leaseDuration = 15 * time.Second
+	renewDeadline = 10 * time.Second
+	retryPeriod   = 2 * time.Second
+)
+
+// Runnable is a background controller's blocking entry point - both
+// *reaper.Reaper and *controller.ManagedNamespaceController implement this
+// via their Start method.
+type Runnable interface {
+	Start(ctx context.Context) error
+}
+
+// Run runs r.Start only while this process holds the leaseName lease, so
+// that multiple replicas of namespace-manager don't race each other
+// performing the same reconciliation. component is used only to prefix log
+// messages (e.g. "reaper", "controller"). It blocks until ctx is cancelled
+// or, after winning the lease, until this process loses it; it does not
+// campaign for the lease again after losing it.
+func Run(ctx context.Context, clientset kubernetes.Interface, leaseName, component string, r Runnable) error {
+	identity, err := os.Hostname()
+	if err != nil {
+		identity = "unknown"
+	}
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      leaseName,
+			Namespace: leaseNamespace,
+		},
+		Client: clientset.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: identity,
+		},
+	}
+
+	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		ReleaseOnCancel: true,
+		LeaseDuration:   leaseDuration,
+		RenewDeadline:   renewDeadline,
+		RetryPeriod:     retryPeriod,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(leaderCtx context.Context) {
+				log.Printf("%s: %s acquired leadership, starting reconciliation", component, identity)
+				if err := r.Start(leaderCtx); err != nil {
+					log.Printf("%s: exited with error: %v", component, err)
+				}
+			},
+			OnStoppedLeading: func() {
+				log.Printf("%s: %s lost leadership, stopping reconciliation", component, identity)
+			},
+			OnNewLeader: func(newLeader string) {
+				if newLeader != identity {
+					log.Printf("%s: %s is now leader", component, newLeader)
+				}
+			},
+		},
+	})
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package reaper
+
+import (
+	"context"
+
+	"k8s.io/client-go/kubernetes"
+
+	"namespace-manager/internal/leader"
+)
+
+const leaseName = "namespace-manager-reaper"
+
+// RunWithLeaderElection runs r.Start only while this process holds the
+// reaper's lease, so that multiple replicas of namespace-manager don't race
+// each other deleting the same expired namespace. It blocks until ctx is
+// cancelled or this process loses the lease, and does not campaign for it
+// again after losing it.
+func RunWithLeaderElection(ctx context.Context, clientset kubernetes.Interface, r *Reaper) error {
+	return leader.Run(ctx, clientset, leaseName, "reaper", r)
+}
@@ -0,0 +1,25 @@
+package reaper
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Metrics tracks reaper outcomes for Prometheus scraping.
+var (
+	reapedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "nsmgr_reaper_reaped_total",
+		Help: "Number of namespaces successfully deleted by the TTL reaper.",
+	})
+
+	failedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "nsmgr_reaper_failed_total",
+		Help: "Number of namespace deletions attempted by the TTL reaper that failed.",
+	})
+
+	skippedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "nsmgr_reaper_skipped_total",
+		Help: "Number of expired namespaces the TTL reaper skipped deleting because it is running in dry-run mode.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(reapedTotal, failedTotal, skippedTotal)
+}
@@ -0,0 +1,243 @@
+// Package reaper implements a background controller that enforces the
+// expires_at annotation the managednamespace controller sets on every
+// namespace it reconciles. Nothing else in the codebase deletes namespaces
+// once they expire - the reaper is what turns that annotation into an
+// actual guarantee.
+package reaper
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+
+	"namespace-manager/internal/kube"
+)
+
+const (
+	// GracePeriodAnnotation lets an individual namespace push its deletion
+	// back past expires_at, e.g. "30m". Parsed with time.ParseDuration.
+	GracePeriodAnnotation = "ttl.grace_period"
+
+	// ProtectedAnnotation, when set to "true", exempts a namespace from
+	// reaping entirely regardless of expires_at.
+	ProtectedAnnotation = "protected"
+
+	// resyncPeriod is how often the informer replays its whole cache
+	// through the event handlers, independent of real API server changes.
+	resyncPeriod = 10 * time.Minute
+)
+
+// entry is what the reaper keeps in its in-memory index for a watched
+// namespace - just enough to decide whether to reap it and to answer the
+// expiring-soon endpoint without hitting the API server.
+type entry struct {
+	info      kube.NamespaceInfo
+	grace     time.Duration
+	protected bool
+}
+
+// Reaper watches namespaces via a shared informer, maintains an in-memory
+// index keyed by namespace name (each entry holding its expires_at), and
+// deletes namespaces once they expire. The index is not ordered by
+// expires_at, so every reconcile scans all of its entries.
+type Reaper struct {
+	kubeClient *kube.Client
+	interval   time.Duration
+	dryRun     bool
+
+	mu    sync.RWMutex
+	index map[string]entry
+
+	informerFactory informers.SharedInformerFactory
+}
+
+// New creates a Reaper. interval controls how often it reconciles on a
+// timer, in addition to reacting to informer add/update/delete events.
+// When dryRun is true the reaper logs what it would delete but never calls
+// DeleteNamespace.
+func New(kubeClient *kube.Client, interval time.Duration, dryRun bool) *Reaper {
+	return &Reaper{
+		kubeClient:      kubeClient,
+		interval:        interval,
+		dryRun:          dryRun,
+		index:           make(map[string]entry),
+		informerFactory: informers.NewSharedInformerFactory(kubeClient.Clientset(), resyncPeriod),
+	}
+}
+
+// Start wires up the namespace informer and runs the reconcile loop until
+// ctx is cancelled. It blocks, so callers should run it in its own
+// goroutine.
+func (r *Reaper) Start(ctx context.Context) error {
+	nsInformer := r.informerFactory.Core().V1().Namespaces().Informer()
+
+	_, err := nsInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { r.updateIndex(obj) },
+		UpdateFunc: func(_, newObj interface{}) { r.updateIndex(newObj) },
+		DeleteFunc: func(obj interface{}) { r.removeFromIndex(obj) },
+	})
+	if err != nil {
+		return err
+	}
+
+	r.informerFactory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), nsInformer.HasSynced) {
+		return ctx.Err()
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	log.Printf("reaper: started, reconciling every %s (dry-run=%v)", r.interval, r.dryRun)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("reaper: stopping")
+			return nil
+		case <-ticker.C:
+			r.reconcile()
+		}
+	}
+}
+
+func (r *Reaper) updateIndex(obj interface{}) {
+	ns, ok := obj.(*corev1.Namespace)
+	if !ok {
+		return
+	}
+
+	e, ok := entryFromNamespace(ns)
+	if !ok {
+		// No expires_at annotation - nothing for the reaper to track.
+		r.removeFromIndexByName(ns.Name)
+		return
+	}
+
+	r.mu.Lock()
+	r.index[ns.Name] = e
+	r.mu.Unlock()
+}
+
+func (r *Reaper) removeFromIndex(obj interface{}) {
+	ns, ok := obj.(*corev1.Namespace)
+	if !ok {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			ns, ok = tombstone.Obj.(*corev1.Namespace)
+			if !ok {
+				return
+			}
+		} else {
+			return
+		}
+	}
+	r.removeFromIndexByName(ns.Name)
+}
+
+func (r *Reaper) removeFromIndexByName(name string) {
+	r.mu.Lock()
+	delete(r.index, name)
+	r.mu.Unlock()
+}
+
+// entryFromNamespace parses the annotations the reaper cares about off a
+// namespace. The second return value is false when there's no expires_at
+// to track.
+func entryFromNamespace(ns *corev1.Namespace) (entry, bool) {
+	annotations := ns.Annotations
+	expiresAtStr := annotations["expires_at"]
+	if expiresAtStr == "" {
+		return entry{}, false
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, expiresAtStr)
+	if err != nil {
+		return entry{}, false
+	}
+
+	var grace time.Duration
+	if gs := annotations[GracePeriodAnnotation]; gs != "" {
+		if d, err := time.ParseDuration(gs); err == nil {
+			grace = d
+		}
+	}
+
+	return entry{
+		info: kube.NamespaceInfo{
+			Name:      ns.Name,
+			Owner:     annotations["owner"],
+			Team:      annotations["team"],
+			CreatedAt: ns.CreationTimestamp.Time,
+			ExpiresAt: expiresAt,
+		},
+		grace:     grace,
+		protected: annotations[ProtectedAnnotation] == "true",
+	}, true
+}
+
+// reconcile walks the current index and reaps anything whose expires_at
+// (plus grace period) has passed.
+func (r *Reaper) reconcile() {
+	r.mu.RLock()
+	due := make([]entry, 0)
+	now := time.Now()
+	for _, e := range r.index {
+		if e.protected {
+			continue
+		}
+		if now.Before(e.info.ExpiresAt.Add(e.grace)) {
+			continue
+		}
+		due = append(due, e)
+	}
+	r.mu.RUnlock()
+
+	for _, e := range due {
+		if r.dryRun {
+			log.Printf("reaper: dry-run, would delete expired namespace %q (owner=%s team=%s expired_at=%s)",
+				e.info.Name, e.info.Owner, e.info.Team, e.info.ExpiresAt)
+			skippedTotal.Inc()
+			continue
+		}
+
+		log.Printf("reaper: deleting expired namespace %q (owner=%s team=%s expired_at=%s)",
+			e.info.Name, e.info.Owner, e.info.Team, e.info.ExpiresAt)
+		if err := r.kubeClient.DeleteNamespace(e.info.Name); err != nil {
+			log.Printf("reaper: failed to delete namespace %q: %v", e.info.Name, err)
+			failedTotal.Inc()
+			continue
+		}
+		reapedTotal.Inc()
+		r.removeFromIndexByName(e.info.Name)
+	}
+}
+
+// ExpiringWithin returns namespaces whose expires_at (plus grace period)
+// falls no later than d from now, including ones already past it that are
+// still in the index, sorted by nothing in particular - callers that
+// need an order should sort the result themselves. Protected namespaces are
+// still reported here since "expiring" and "exempt from reaping" are
+// different questions.
+func (r *Reaper) ExpiringWithin(d time.Duration) []kube.NamespaceInfo {
+	deadline := time.Now().Add(d)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]kube.NamespaceInfo, 0)
+	for _, e := range r.index {
+		effective := e.info.ExpiresAt.Add(e.grace)
+		if effective.After(deadline) {
+			continue
+		}
+		info := e.info
+		info.TTL = int(time.Until(effective).Hours())
+		result = append(result, info)
+	}
+	return result
+}